@@ -0,0 +1,90 @@
+package logx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+)
+
+func TestFields(t *testing.T) {
+	var buf bytes.Buffer
+	logger := Register("test-fields", NewOptions(WithOutput(&buf)))
+
+	derived := logger.Fields("request_id", "abc-123")
+	derived.InfoContext(context.Background(), "hello")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to parse log output: %v", err)
+	}
+	if entry["request_id"] != "abc-123" {
+		t.Errorf("expected request_id = abc-123, got %v", entry["request_id"])
+	}
+}
+
+func TestWithContextAttrFunc(t *testing.T) {
+	var buf bytes.Buffer
+	opts := NewOptions(
+		WithOutput(&buf),
+		WithContextAttrFunc(func(ctx context.Context) []any {
+			return []any{"trace_id", "t-1", "span_id", "s-1"}
+		}),
+	)
+	logger := Register("test-attr-func", opts)
+
+	logger.InfoContext(context.Background(), "hello")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to parse log output: %v", err)
+	}
+	if entry["trace_id"] != "t-1" || entry["span_id"] != "s-1" {
+		t.Errorf("expected trace_id and span_id attrs, got %v", entry)
+	}
+}
+
+func TestSetDefaultContextAttrFuncs(t *testing.T) {
+	orig := getDefaultContextAttrFuncs()
+	defer SetDefaultContextAttrFuncs(orig...)
+
+	SetDefaultContextAttrFuncs(func(ctx context.Context) []any {
+		return []any{"tenant", "acme"}
+	})
+
+	var buf bytes.Buffer
+	logger := Register("test-default-attr-func", NewOptions(WithOutput(&buf)))
+	logger.InfoContext(context.Background(), "hello")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to parse log output: %v", err)
+	}
+	if entry["tenant"] != "acme" {
+		t.Errorf("expected tenant = acme from SetDefaultContextAttrFuncs, got %v", entry["tenant"])
+	}
+}
+
+func TestSetDefaultContextAttrFuncsConcurrent(t *testing.T) {
+	orig := getDefaultContextAttrFuncs()
+	defer SetDefaultContextAttrFuncs(orig...)
+
+	logger := Register("test-default-attr-func-race", NewOptions(WithOutput(&bytes.Buffer{})))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			logger.InfoContext(context.Background(), "hello")
+		}()
+		go func() {
+			defer wg.Done()
+			SetDefaultContextAttrFuncs(func(ctx context.Context) []any {
+				return []any{"tenant", "acme"}
+			})
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,110 @@
+package logx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestDefaultReplaceAttrShortensSource(t *testing.T) {
+	var buf bytes.Buffer
+	logger := Register("test-source", NewOptions(WithOutput(&buf), WithAddSource(true)))
+
+	logger.InfoContext(context.Background(), "hello")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to parse log output: %v", err)
+	}
+	source, ok := entry["source"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected a source attribute, got %v", entry)
+	}
+	file, _ := source["file"].(string)
+	if strings.Contains(file, "/") {
+		t.Errorf("expected source.file to be a base name, got %q", file)
+	}
+	if !strings.HasSuffix(file, "_test.go") {
+		t.Errorf("expected source.file to point at the test call site, got %q", file)
+	}
+}
+
+func TestWithReplaceAttrOverride(t *testing.T) {
+	var buf bytes.Buffer
+	opts := NewOptions(
+		WithOutput(&buf),
+		WithReplaceAttr(func(_ []string, a slog.Attr) slog.Attr {
+			if a.Key == slog.MessageKey {
+				return slog.String(slog.MessageKey, "redacted")
+			}
+			return a
+		}),
+	)
+	logger := Register("test-replace-attr", opts)
+
+	logger.InfoContext(context.Background(), "secret message")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to parse log output: %v", err)
+	}
+	if entry["msg"] != "redacted" {
+		t.Errorf("expected custom ReplaceAttr to redact msg, got %v", entry["msg"])
+	}
+}
+
+func TestWithCallerSkip(t *testing.T) {
+	var buf bytes.Buffer
+	logger := Register("test-caller-skip", NewOptions(WithOutput(&buf), WithCallerSkip(1)))
+
+	logWrapper(logger, context.Background(), "hello")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to parse log output: %v", err)
+	}
+	source, ok := entry["source"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected a source attribute, got %v", entry)
+	}
+	if function, _ := source["function"].(string); !strings.HasSuffix(function, ".TestWithCallerSkip") {
+		t.Errorf("expected source.function to skip past logWrapper to its caller, got %q", function)
+	}
+}
+
+// logWrapper stands in for a caller-supplied helper that wraps *Logx, to
+// exercise WithCallerSkip.
+func logWrapper(l *Logx, ctx context.Context, msg string) {
+	l.InfoContext(ctx, msg)
+}
+
+func TestPackageLevelContextFuncsSourceFrame(t *testing.T) {
+	var buf bytes.Buffer
+	logger := Register("test-pkg-source", NewOptions(WithOutput(&buf), WithAddSource(true)))
+	ctx := NewContext(context.Background(), logger)
+
+	InfoContext(ctx, "hello")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to parse log output: %v", err)
+	}
+	source, ok := entry["source"].(map[string]interface{})
+	if !ok {
+		t.Fatalf("expected a source attribute, got %v", entry)
+	}
+	file, _ := source["file"].(string)
+	if file == "context.go" {
+		t.Errorf("expected package-level InfoContext to report the caller's file, got logx's own %q", file)
+	}
+	if !strings.HasSuffix(file, "_test.go") {
+		t.Errorf("expected source.file to point at the test call site, got %q", file)
+	}
+	function, _ := source["function"].(string)
+	if strings.HasSuffix(function, ".InfoContext") {
+		t.Errorf("expected source.function to point at the test, got %q", function)
+	}
+}
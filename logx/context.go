@@ -0,0 +1,64 @@
+package logx
+
+import (
+	"context"
+	"log/slog"
+)
+
+// loggerContextKey is an unexported type so values stored under it can't
+// collide with keys set by other packages.
+type loggerContextKey struct{}
+
+// NewContext returns a copy of ctx carrying l, retrievable later with
+// FromContext. This lets middleware attach a request-scoped *Logx (e.g.
+// already decorated via Fields with a request ID) so downstream code can
+// log against it without plumbing the logger through every function
+// signature.
+func NewContext(ctx context.Context, l *Logx) context.Context {
+	return context.WithValue(ctx, loggerContextKey{}, l)
+}
+
+// FromContext returns the *Logx stored in ctx by NewContext, or nil if
+// none is present.
+func FromContext(ctx context.Context) *Logx {
+	l, _ := ctx.Value(loggerContextKey{}).(*Logx)
+	return l
+}
+
+// loggerFromContext returns the *Logx stored in ctx, falling back to
+// Default() when none is present.
+func loggerFromContext(ctx context.Context) *Logx {
+	if l := FromContext(ctx); l != nil {
+		return l
+	}
+	return Default()
+}
+
+// InfoContext logs a message at Info level using the *Logx stored in ctx
+// by NewContext, or the default logger if ctx carries none.
+//
+// This calls logContext directly rather than the InfoContext method so
+// that the AddSource frame it resolves sits at the same stack depth as a
+// direct *Logx.InfoContext call, regardless of which entry point the
+// caller used.
+func InfoContext(ctx context.Context, msg string, args ...any) {
+	loggerFromContext(ctx).logContext(ctx, slog.LevelInfo, msg, args...)
+}
+
+// DebugContext logs a message at Debug level using the *Logx stored in ctx
+// by NewContext, or the default logger if ctx carries none.
+func DebugContext(ctx context.Context, msg string, args ...any) {
+	loggerFromContext(ctx).logContext(ctx, slog.LevelDebug, msg, args...)
+}
+
+// ErrorContext logs a message at Error level using the *Logx stored in ctx
+// by NewContext, or the default logger if ctx carries none.
+func ErrorContext(ctx context.Context, msg string, args ...any) {
+	loggerFromContext(ctx).logContext(ctx, slog.LevelError, msg, args...)
+}
+
+// WarnContext logs a message at Warn level using the *Logx stored in ctx
+// by NewContext, or the default logger if ctx carries none.
+func WarnContext(ctx context.Context, msg string, args ...any) {
+	loggerFromContext(ctx).logContext(ctx, slog.LevelWarn, msg, args...)
+}
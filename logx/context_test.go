@@ -0,0 +1,39 @@
+package logx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestNewContextFromContext(t *testing.T) {
+	var buf bytes.Buffer
+	logger := Register("test-embed", NewOptions(WithOutput(&buf))).Fields("request_id", "req-42")
+
+	ctx := NewContext(context.Background(), logger)
+	got := FromContext(ctx)
+	if got != logger {
+		t.Fatalf("FromContext() = %v, want %v", got, logger)
+	}
+
+	if FromContext(context.Background()) != nil {
+		t.Error("FromContext() on a plain context should return nil")
+	}
+}
+
+func TestPackageLevelContextLogging(t *testing.T) {
+	var buf bytes.Buffer
+	logger := Register("test-embed-log", NewOptions(WithOutput(&buf))).Fields("request_id", "req-99")
+	ctx := NewContext(context.Background(), logger)
+
+	InfoContext(ctx, "hello")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("failed to parse log output: %v", err)
+	}
+	if entry["request_id"] != "req-99" {
+		t.Errorf("expected request_id = req-99, got %v", entry["request_id"])
+	}
+}
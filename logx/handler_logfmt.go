@@ -0,0 +1,144 @@
+package logx
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// logfmtHandler is a minimal slog.Handler that writes key=value pairs,
+// one record per line, since the standard library doesn't ship one.
+type logfmtHandler struct {
+	mu     *sync.Mutex
+	w      io.Writer
+	opts   *slog.HandlerOptions
+	attrs  []slog.Attr
+	groups []string
+}
+
+// newLogfmtHandler creates a logfmt handler writing to w.
+func newLogfmtHandler(w io.Writer, opts *slog.HandlerOptions) *logfmtHandler {
+	if opts == nil {
+		opts = &slog.HandlerOptions{}
+	}
+	return &logfmtHandler{mu: &sync.Mutex{}, w: w, opts: opts}
+}
+
+// Enabled reports whether the handler handles records at the given level.
+func (h *logfmtHandler) Enabled(_ context.Context, level slog.Level) bool {
+	minLevel := slog.LevelInfo
+	if h.opts.Level != nil {
+		minLevel = h.opts.Level.Level()
+	}
+	return level >= minLevel
+}
+
+// Handle formats r as logfmt and writes it, guarded by a shared mutex so
+// concurrent callers and derived handlers (via WithAttrs/WithGroup) don't
+// interleave writes.
+func (h *logfmtHandler) Handle(_ context.Context, r slog.Record) error {
+	var buf bytes.Buffer
+	writeLogfmtPair(&buf, "time", r.Time.Format(time.RFC3339))
+	writeLogfmtPair(&buf, "level", r.Level.String())
+	writeLogfmtPair(&buf, "msg", r.Message)
+
+	if h.opts.AddSource && r.PC != 0 {
+		frames := runtime.CallersFrames([]uintptr{r.PC})
+		frame, _ := frames.Next()
+		if frame.File != "" {
+			writeLogfmtPair(&buf, "source", fmt.Sprintf("%s:%d", filepath.Base(frame.File), frame.Line))
+		}
+	}
+
+	for _, a := range h.attrs {
+		h.writeAttr(&buf, "", a)
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		h.writeAttr(&buf, h.groupPrefix(), a)
+		return true
+	})
+	buf.WriteByte('\n')
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := h.w.Write(buf.Bytes())
+	return err
+}
+
+func (h *logfmtHandler) groupPrefix() string {
+	if len(h.groups) == 0 {
+		return ""
+	}
+	return strings.Join(h.groups, ".") + "."
+}
+
+func (h *logfmtHandler) writeAttr(buf *bytes.Buffer, prefix string, a slog.Attr) {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return
+	}
+	key := prefix + a.Key
+	if a.Value.Kind() == slog.KindGroup {
+		for _, ga := range a.Value.Group() {
+			h.writeAttr(buf, key+".", ga)
+		}
+		return
+	}
+	writeLogfmtPair(buf, key, a.Value.String())
+}
+
+func writeLogfmtPair(buf *bytes.Buffer, key, value string) {
+	if buf.Len() > 0 {
+		buf.WriteByte(' ')
+	}
+	buf.WriteString(key)
+	buf.WriteByte('=')
+	if needsQuote(value) {
+		buf.WriteString(strconv.Quote(value))
+	} else {
+		buf.WriteString(value)
+	}
+}
+
+func needsQuote(s string) bool {
+	if s == "" {
+		return true
+	}
+	for _, r := range s {
+		if r <= ' ' || r == '"' || r == '=' {
+			return true
+		}
+	}
+	return false
+}
+
+// WithAttrs returns a new handler with the given attributes appended,
+// sharing the same underlying writer and mutex.
+func (h *logfmtHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	prefix := h.groupPrefix()
+	newAttrs := make([]slog.Attr, 0, len(h.attrs)+len(attrs))
+	newAttrs = append(newAttrs, h.attrs...)
+	for _, a := range attrs {
+		if prefix != "" {
+			a.Key = prefix + a.Key
+		}
+		newAttrs = append(newAttrs, a)
+	}
+	return &logfmtHandler{mu: h.mu, w: h.w, opts: h.opts, attrs: newAttrs, groups: h.groups}
+}
+
+// WithGroup returns a new handler that nests subsequent attributes under name.
+func (h *logfmtHandler) WithGroup(name string) slog.Handler {
+	groups := make([]string, 0, len(h.groups)+1)
+	groups = append(groups, h.groups...)
+	groups = append(groups, name)
+	return &logfmtHandler{mu: h.mu, w: h.w, opts: h.opts, attrs: h.attrs, groups: groups}
+}
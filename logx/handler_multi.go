@@ -0,0 +1,60 @@
+package logx
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+)
+
+// multiHandler fans a record out to several handlers, e.g. JSON to a file
+// and pretty text to stderr at the same time.
+type multiHandler struct {
+	handlers []slog.Handler
+}
+
+// newMultiHandler returns a handler that dispatches to all of handlers.
+func newMultiHandler(handlers ...slog.Handler) *multiHandler {
+	return &multiHandler{handlers: handlers}
+}
+
+// Enabled reports whether at least one child handler handles level.
+func (m *multiHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, h := range m.handlers {
+		if h.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+// Handle dispatches r to every enabled child handler, collecting errors.
+func (m *multiHandler) Handle(ctx context.Context, r slog.Record) error {
+	var errs []error
+	for _, h := range m.handlers {
+		if !h.Enabled(ctx, r.Level) {
+			continue
+		}
+		if err := h.Handle(ctx, r.Clone()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// WithAttrs returns a multiHandler whose children each carry the given attrs.
+func (m *multiHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make([]slog.Handler, len(m.handlers))
+	for i, h := range m.handlers {
+		handlers[i] = h.WithAttrs(attrs)
+	}
+	return newMultiHandler(handlers...)
+}
+
+// WithGroup returns a multiHandler whose children each nest under name.
+func (m *multiHandler) WithGroup(name string) slog.Handler {
+	handlers := make([]slog.Handler, len(m.handlers))
+	for i, h := range m.handlers {
+		handlers[i] = h.WithGroup(name)
+	}
+	return newMultiHandler(handlers...)
+}
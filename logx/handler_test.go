@@ -0,0 +1,100 @@
+package logx
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestWithFormatText(t *testing.T) {
+	var buf bytes.Buffer
+	opts := NewOptions(WithFormat(FormatText), WithOutput(&buf))
+	logger := Register("test-format-text", opts)
+
+	logger.InfoContext(context.Background(), "hello")
+
+	out := buf.String()
+	if !strings.Contains(out, "msg=hello") {
+		t.Errorf("expected text-formatted output, got %q", out)
+	}
+}
+
+func TestWithFormatLogfmt(t *testing.T) {
+	var buf bytes.Buffer
+	opts := NewOptions(WithFormat(FormatLogfmt), WithOutput(&buf))
+	logger := Register("test-format-logfmt", opts)
+
+	logger.InfoContext(context.Background(), "hello", "key", "value")
+
+	out := buf.String()
+	if !strings.Contains(out, "msg=hello") || !strings.Contains(out, "key=value") {
+		t.Errorf("expected logfmt output with msg and key pairs, got %q", out)
+	}
+}
+
+func TestWithHandler(t *testing.T) {
+	var buf bytes.Buffer
+	custom := slog.NewJSONHandler(&buf, &slog.HandlerOptions{})
+	opts := NewOptions(WithHandler(custom))
+	logger := Register("test-custom-handler", opts)
+
+	logger.InfoContext(context.Background(), "hello")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &entry); err != nil {
+		t.Fatalf("expected JSON from custom handler: %v", err)
+	}
+	if entry["module"] != "test-custom-handler" {
+		t.Errorf("expected module attribute to be preserved, got %v", entry["module"])
+	}
+}
+
+func TestWithOutputsFanOut(t *testing.T) {
+	var jsonBuf, textBuf bytes.Buffer
+	opts := NewOptions(
+		WithOutputs(&jsonBuf, &textBuf),
+		WithFormat(FormatJSON),
+	)
+	logger := Register("test-fan-out", opts)
+
+	logger.InfoContext(context.Background(), "hello")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(jsonBuf.Bytes(), &entry); err != nil {
+		t.Fatalf("expected JSON on first sink: %v", err)
+	}
+	if textBuf.Len() == 0 {
+		t.Error("expected second sink to also receive the record")
+	}
+}
+
+func TestWithFormattedOutputsMixedFormats(t *testing.T) {
+	var jsonBuf, textBuf bytes.Buffer
+	opts := NewOptions(
+		WithFormattedOutputs(
+			Sink{Writer: &jsonBuf, Format: FormatJSON},
+			Sink{Writer: &textBuf, Format: FormatText},
+		),
+	)
+	logger := Register("test-formatted-outputs", opts)
+
+	logger.InfoContext(context.Background(), "hello")
+
+	var entry map[string]interface{}
+	if err := json.Unmarshal(jsonBuf.Bytes(), &entry); err != nil {
+		t.Fatalf("expected JSON on the first sink: %v", err)
+	}
+	if entry["msg"] != "hello" {
+		t.Errorf("expected msg = hello on the JSON sink, got %v", entry["msg"])
+	}
+
+	if err := json.Unmarshal(textBuf.Bytes(), &entry); err == nil {
+		t.Errorf("expected non-JSON text output on the second sink, got %q", textBuf.String())
+	}
+	if !strings.Contains(textBuf.String(), "msg=hello") {
+		t.Errorf("expected text-formatted output on the second sink, got %q", textBuf.String())
+	}
+}
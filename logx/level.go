@@ -0,0 +1,36 @@
+package logx
+
+import "log/slog"
+
+// SetLevel raises or lowers the verbosity of the named module's logger at
+// runtime. It has no effect if the module hasn't been registered yet.
+// Because the level is backed by a slog.LevelVar shared with the
+// logger's handler(s), the change takes effect immediately, including for
+// child loggers already created via With. A logger built with WithHandler
+// filters by its own handler's level, which SetLevel does not change.
+func SetLevel(moduleName string, level slog.Level) {
+	logger, ok := GetLogger(moduleName)
+	if !ok {
+		return
+	}
+	logger.levelVar.Set(level)
+}
+
+// SetAllLevels sets the level of every registered module's logger at once.
+func SetAllLevels(level slog.Level) {
+	moduleLoggers.Range(func(_, value any) bool {
+		value.(*Logx).levelVar.Set(level)
+		return true
+	})
+}
+
+// Levels returns the current level of every registered module's logger,
+// keyed by module name.
+func Levels() map[string]slog.Level {
+	levels := make(map[string]slog.Level)
+	moduleLoggers.Range(func(key, value any) bool {
+		levels[key.(string)] = value.(*Logx).levelVar.Level()
+		return true
+	})
+	return levels
+}
@@ -0,0 +1,41 @@
+package logx
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+)
+
+func TestSetLevel(t *testing.T) {
+	var buf bytes.Buffer
+	opts := NewOptions(WithLevel(slog.LevelInfo), WithOutput(&buf))
+	Register("test-set-level", opts)
+
+	logger, _ := GetLogger("test-set-level")
+	logger.DebugContext(context.Background(), "should not appear")
+	if buf.Len() != 0 {
+		t.Fatalf("expected debug message to be filtered out, got %q", buf.String())
+	}
+
+	SetLevel("test-set-level", slog.LevelDebug)
+	logger.DebugContext(context.Background(), "should appear")
+	if buf.Len() == 0 {
+		t.Error("expected debug message after raising level, got none")
+	}
+}
+
+func TestSetAllLevelsAndLevels(t *testing.T) {
+	Register("test-all-levels-a", NewOptions(WithLevel(slog.LevelInfo)))
+	Register("test-all-levels-b", NewOptions(WithLevel(slog.LevelWarn)))
+
+	SetAllLevels(slog.LevelError)
+
+	levels := Levels()
+	if levels["test-all-levels-a"] != slog.LevelError {
+		t.Errorf("expected module a at LevelError, got %v", levels["test-all-levels-a"])
+	}
+	if levels["test-all-levels-b"] != slog.LevelError {
+		t.Errorf("expected module b at LevelError, got %v", levels["test-all-levels-b"])
+	}
+}
@@ -6,8 +6,12 @@ package logx
 
 import (
 	"context"
+	"io"
 	"log/slog"
+	"os"
+	"runtime"
 	"sync"
+	"time"
 )
 
 // Logx represents a logger instance for a specific module.
@@ -18,6 +22,39 @@ type Logx struct {
 	*slog.Logger
 	moduleName        string
 	contextExtractors map[string]ContextExtractor
+	contextAttrFuncs  []ContextAttrFunc
+	levelVar          *slog.LevelVar
+	callerSkip        int
+}
+
+// defaultContextAttrFuncs are ContextAttrFuncs run for every module's log
+// entries, in addition to any registered on the module itself via
+// WithContextAttrFunc. Guarded by defaultContextAttrFuncsMu since, unlike
+// per-logger options, this is process-wide state that can be read
+// concurrently with logging from every module; use
+// SetDefaultContextAttrFuncs to change it rather than a bare assignment.
+var (
+	defaultContextAttrFuncsMu sync.RWMutex
+	defaultContextAttrFuncs   []ContextAttrFunc
+)
+
+// SetDefaultContextAttrFuncs replaces the process-wide ContextAttrFuncs run
+// for every module's log entries. Register cross-cutting extractors here
+// once at process startup (e.g. trace ID, tenant) so they automatically
+// flow into every module's InfoContext/ErrorContext/etc without
+// per-module wiring. Safe to call concurrently with logging.
+func SetDefaultContextAttrFuncs(funcs ...ContextAttrFunc) {
+	defaultContextAttrFuncsMu.Lock()
+	defer defaultContextAttrFuncsMu.Unlock()
+	defaultContextAttrFuncs = funcs
+}
+
+// getDefaultContextAttrFuncs returns the current process-wide
+// ContextAttrFuncs set via SetDefaultContextAttrFuncs.
+func getDefaultContextAttrFuncs() []ContextAttrFunc {
+	defaultContextAttrFuncsMu.RLock()
+	defer defaultContextAttrFuncsMu.RUnlock()
+	return defaultContextAttrFuncs
 }
 
 // moduleLoggers is a thread-safe map storing all registered logger instances.
@@ -101,60 +138,165 @@ func Default() *Logx {
 //   - msg: The message to log
 //   - args: Additional key-value pairs to include in the log
 func (c *Logx) InfoContext(ctx context.Context, msg string, args ...any) {
-	args = setValueFromContext(ctx, c.contextExtractors, args...)
-	c.Logger.InfoContext(ctx, msg, args...)
+	c.logContext(ctx, slog.LevelInfo, msg, args...)
 }
 
 // DebugContext logs a message at Debug level with context-extracted values.
 func (c *Logx) DebugContext(ctx context.Context, msg string, args ...any) {
-	args = setValueFromContext(ctx, c.contextExtractors, args...)
-	c.Logger.DebugContext(ctx, msg, args...)
+	c.logContext(ctx, slog.LevelDebug, msg, args...)
 }
 
 // ErrorContext logs a message at Error level with context-extracted values.
 func (c *Logx) ErrorContext(ctx context.Context, msg string, args ...any) {
-	args = setValueFromContext(ctx, c.contextExtractors, args...)
-	c.Logger.ErrorContext(ctx, msg, args...)
+	c.logContext(ctx, slog.LevelError, msg, args...)
 }
 
 // WarnContext logs a message at Warn level with context-extracted values.
 func (c *Logx) WarnContext(ctx context.Context, msg string, args ...any) {
-	args = setValueFromContext(ctx, c.contextExtractors, args...)
-	c.Logger.WarnContext(ctx, msg, args...)
+	c.logContext(ctx, slog.LevelWarn, msg, args...)
+}
+
+// logContext extracts context attributes and emits the record. It is the
+// single entry point used by both the InfoContext/DebugContext/
+// ErrorContext/WarnContext methods and the package-level functions in
+// context.go, so that every public entry point sits exactly one call deep
+// above it and log's caller-skip arithmetic doesn't depend on which one
+// was used.
+func (c *Logx) logContext(ctx context.Context, level slog.Level, msg string, args ...any) {
+	args = setValueFromContext(ctx, c.contextExtractors, c.contextAttrFuncs, args...)
+	c.log(ctx, level, msg, args...)
+}
+
+// log builds and emits a slog.Record directly, rather than going through
+// the convenience c.Logger.InfoContext/etc, so that the AddSource frame
+// resolves to the user's call site instead of to this package. callerSkip,
+// set via WithCallerSkip, adds further skip for callers who wrap *Logx in
+// their own helper.
+func (c *Logx) log(ctx context.Context, level slog.Level, msg string, args ...any) {
+	if !c.Logger.Enabled(ctx, level) {
+		return
+	}
+	var pcs [1]uintptr
+	runtime.Callers(4+c.callerSkip, pcs[:]) // skip runtime.Callers, log, logContext, and the public entry point
+	r := slog.NewRecord(time.Now(), level, msg, pcs[0])
+	r.Add(args...)
+	_ = c.Logger.Handler().Handle(ctx, r)
 }
 
 // configLogger configures a logger instance with the provided options.
-// It sets up the JSON handler, source addition, log level, and context extractors.
-// If options is nil, default options are used.
+// It sets up the handler (format, custom, or multi-sink), source addition,
+// log level, and context extractors. If options is nil, default options
+// are used.
 func configLogger(logger *Logx, options *Options) {
 	if options == nil {
 		options = defaultOptions()
 	}
+	levelVar := &slog.LevelVar{}
+	levelVar.Set(options.level)
+
 	slogOpts := &slog.HandlerOptions{
-		AddSource: options.addSource,
-		Level:     options.level,
+		AddSource:   options.addSource,
+		Level:       levelVar,
+		ReplaceAttr: options.replaceAttr,
 	}
 
-	handler := slog.NewJSONHandler(options.output, slogOpts)
+	handler := buildHandler(options, slogOpts)
 	logger.Logger = slog.New(handler)
 	logger.contextExtractors = options.contextExtractors
+	logger.contextAttrFuncs = options.contextAttrFuncs
+	logger.levelVar = levelVar
+	logger.callerSkip = options.callerSkip
 	logger.Logger = logger.Logger.With(slog.String("module", logger.moduleName))
 }
 
-// setValueFromContext extracts values from context using registered extractors
-// and appends them to the provided arguments list.
+// Fields returns a copy of the logger with args merged in as persistent
+// attributes on every subsequent log entry, via slog.Logger.With. The
+// original logger is left untouched.
+func (c *Logx) Fields(args ...any) *Logx {
+	return &Logx{
+		Logger:            c.Logger.With(args...),
+		moduleName:        c.moduleName,
+		contextExtractors: c.contextExtractors,
+		contextAttrFuncs:  c.contextAttrFuncs,
+		levelVar:          c.levelVar,
+		callerSkip:        c.callerSkip,
+	}
+}
+
+// buildHandler resolves the slog.Handler for a logger from its options:
+// a caller-supplied handler wins outright, per-sink formats (WithFormattedOutputs)
+// come next, then multiple same-format outputs are fanned out through a
+// multiHandler, and a single output gets a handler for the configured
+// format.
+func buildHandler(options *Options, slogOpts *slog.HandlerOptions) slog.Handler {
+	if options.handler != nil {
+		return options.handler
+	}
+
+	if len(options.sinks) > 0 {
+		handlers := make([]slog.Handler, 0, len(options.sinks))
+		for _, s := range options.sinks {
+			handlers = append(handlers, newFormatHandler(s.Format, s.Writer, slogOpts))
+		}
+		if len(handlers) == 1 {
+			return handlers[0]
+		}
+		return newMultiHandler(handlers...)
+	}
+
+	outputs := options.outputs
+	if len(outputs) == 0 {
+		outputs = []io.Writer{os.Stdout}
+	}
+	if len(outputs) == 1 {
+		return newFormatHandler(options.format, outputs[0], slogOpts)
+	}
+
+	handlers := make([]slog.Handler, 0, len(outputs))
+	for _, w := range outputs {
+		handlers = append(handlers, newFormatHandler(options.format, w, slogOpts))
+	}
+	return newMultiHandler(handlers...)
+}
+
+// newFormatHandler builds a handler of the requested format writing to w.
+// It falls back to FormatJSON for an empty or unrecognized format.
+func newFormatHandler(format string, w io.Writer, slogOpts *slog.HandlerOptions) slog.Handler {
+	switch format {
+	case FormatText:
+		return slog.NewTextHandler(w, slogOpts)
+	case FormatLogfmt:
+		return newLogfmtHandler(w, slogOpts)
+	default:
+		return slog.NewJSONHandler(w, slogOpts)
+	}
+}
+
+// setValueFromContext extracts values from context using registered
+// extractors and attribute functions, and appends them to the provided
+// arguments list. It combines the logger's own contextExtractors and
+// contextAttrFuncs with the process-wide funcs set via
+// SetDefaultContextAttrFuncs, so cross-cutting attributes registered once
+// at startup automatically flow into every module's log entries.
 // This is an internal function used by all logging methods.
 // Parameters:
 //   - ctx: The context to extract values from
 //   - ce: Map of context extractors
+//   - af: Per-logger context attribute functions
 //   - args: Existing arguments to append to
 //
 // Returns:
 //   - []any: Combined slice of existing args and extracted values
-func setValueFromContext(ctx context.Context, ce map[string]ContextExtractor, args ...any) []any {
+func setValueFromContext(ctx context.Context, ce map[string]ContextExtractor, af []ContextAttrFunc, args ...any) []any {
 	for key, extractor := range ce {
 		v := extractor(ctx)
 		args = append(args, slog.String(key, v))
 	}
+	for _, f := range af {
+		args = append(args, f(ctx)...)
+	}
+	for _, f := range getDefaultContextAttrFuncs() {
+		args = append(args, f(ctx)...)
+	}
 	return args
 }
@@ -5,19 +5,57 @@ import (
 	"io"
 	"log/slog"
 	"os"
+	"path/filepath"
+)
+
+// Format identifies the wire format a handler built by configLogger writes.
+const (
+	FormatJSON   = "json"
+	FormatText   = "text"
+	FormatLogfmt = "logfmt"
 )
 
 // Options defines the configuration options for a logger instance.
 type Options struct {
 	level             slog.Level
 	addSource         bool
-	output            io.Writer
+	format            string
+	outputs           []io.Writer
+	sinks             []Sink
+	handler           slog.Handler
 	contextExtractors map[string]ContextExtractor
+	contextAttrFuncs  []ContextAttrFunc
+	replaceAttr       func(groups []string, a slog.Attr) slog.Attr
+	callerSkip        int
+	err               error
+}
+
+// Err returns the first error recorded while applying the OptionsFuncs
+// passed to NewOptions, e.g. WithRotatingFile failing to open its target
+// file and falling back to stdout. Callers whose logging destination is
+// load-bearing (production file rotation, say) should check this after
+// NewOptions rather than assume the fallback is silent enough to ignore.
+func (o *Options) Err() error {
+	return o.err
+}
+
+// Sink pairs a writer with the format used for entries written to it, for
+// use with WithFormattedOutputs.
+type Sink struct {
+	Writer io.Writer
+	Format string
 }
 
 // ContextExtractor is a function type that extracts string values from context.
 type ContextExtractor func(ctx context.Context) string
 
+// ContextAttrFunc extracts one or more slog attribute arguments (key-value
+// pairs or slog.Attr values) from a context.Context. Unlike ContextExtractor,
+// which is limited to a single named string, a ContextAttrFunc can emit
+// several typed attributes from one extraction, e.g. both a trace ID and a
+// span ID.
+type ContextAttrFunc func(ctx context.Context) []any
+
 // OptionsFunc is a function type for modifying Options.
 type OptionsFunc func(*Options)
 
@@ -26,9 +64,24 @@ func defaultOptions() *Options {
 	return &Options{
 		level:             slog.LevelInfo,
 		addSource:         true,
-		output:            os.Stdout,
+		format:            FormatJSON,
+		outputs:           []io.Writer{os.Stdout},
 		contextExtractors: make(map[string]ContextExtractor),
+		replaceAttr:       defaultReplaceAttr,
+	}
+}
+
+// defaultReplaceAttr shortens the source file recorded by AddSource to its
+// base name, matching the sparrow/databricks loggers, so entries don't
+// carry the full build-machine path.
+func defaultReplaceAttr(_ []string, a slog.Attr) slog.Attr {
+	if a.Key != slog.SourceKey {
+		return a
+	}
+	if src, ok := a.Value.Any().(*slog.Source); ok {
+		src.File = filepath.Base(src.File)
 	}
+	return a
 }
 
 // WithLevel sets the logging level for the logger.
@@ -50,10 +103,54 @@ func WithAddSource(addSource bool) OptionsFunc {
 func WithOutput(w io.Writer) OptionsFunc {
 	return func(o *Options) {
 		if w == nil {
-			o.output = os.Stdout
+			o.outputs = []io.Writer{os.Stdout}
 			return
 		}
-		o.output = w
+		o.outputs = []io.Writer{w}
+	}
+}
+
+// WithOutputs fans a logger out to multiple writers at once, e.g. a file
+// and stderr. All of them share the format configured via WithFormat;
+// entries are dispatched to every sink through a composite handler. For
+// sinks that need different formats (e.g. JSON to a file and pretty text
+// to stderr), use WithFormattedOutputs instead. If called with no
+// writers, logs go to stdout.
+func WithOutputs(writers ...io.Writer) OptionsFunc {
+	return func(o *Options) {
+		if len(writers) == 0 {
+			o.outputs = []io.Writer{os.Stdout}
+			return
+		}
+		o.outputs = writers
+	}
+}
+
+// WithFormattedOutputs fans a logger out to multiple sinks at once, each
+// with its own format, e.g. JSON to a file and pretty text to stderr like
+// the Databricks CLI logger does. It takes precedence over WithOutput/
+// WithOutputs/WithFormat when set.
+func WithFormattedOutputs(sinks ...Sink) OptionsFunc {
+	return func(o *Options) {
+		o.sinks = sinks
+	}
+}
+
+// WithFormat selects the wire format used by handlers built for this
+// logger's outputs: FormatJSON (default), FormatText, or FormatLogfmt.
+// It has no effect if WithHandler is also used.
+func WithFormat(format string) OptionsFunc {
+	return func(o *Options) {
+		o.format = format
+	}
+}
+
+// WithHandler installs a caller-supplied slog.Handler, bypassing the
+// format/output machinery entirely. Use this as an escape hatch when
+// none of the built-in formats fit.
+func WithHandler(h slog.Handler) OptionsFunc {
+	return func(o *Options) {
+		o.handler = h
 	}
 }
 
@@ -65,6 +162,41 @@ func WithContextExtractor(key string, extractor ContextExtractor) OptionsFunc {
 	}
 }
 
+// WithReplaceAttr sets the slog.HandlerOptions.ReplaceAttr hook used by
+// handlers built for this logger, overriding the default that shortens
+// source.file to its base name. It has no effect if WithHandler is also
+// used, since the caller-supplied handler owns its own HandlerOptions.
+// The FormatLogfmt handler does not call it either: it always writes
+// source as the file's base name and line.
+func WithReplaceAttr(f func(groups []string, a slog.Attr) slog.Attr) OptionsFunc {
+	return func(o *Options) {
+		o.replaceAttr = f
+	}
+}
+
+// WithCallerSkip adjusts how many additional stack frames the Context
+// logging methods (InfoContext, DebugContext, etc.) skip when resolving
+// the source location for AddSource. The methods already account for
+// their own frame; increase this when *Logx is wrapped by further
+// helpers of the caller's own, so the reported file:line still points at
+// the original call site rather than at the wrapper.
+func WithCallerSkip(n int) OptionsFunc {
+	return func(o *Options) {
+		o.callerSkip = n
+	}
+}
+
+// WithContextAttrFunc registers a ContextAttrFunc that runs for every log
+// entry, alongside the process-wide funcs set via SetDefaultContextAttrFuncs.
+// Unlike WithContextExtractor, a single extractor here can emit several
+// typed attributes at once, which is useful for cross-cutting values like
+// trace/span IDs.
+func WithContextAttrFunc(f ContextAttrFunc) OptionsFunc {
+	return func(o *Options) {
+		o.contextAttrFuncs = append(o.contextAttrFuncs, f)
+	}
+}
+
 // NewOptions creates a new Options instance with the provided option functions applied.
 func NewOptions(options ...OptionsFunc) *Options {
 	opts := defaultOptions()
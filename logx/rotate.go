@@ -0,0 +1,227 @@
+package logx
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RotateOptions configures a RotatingFileWriter.
+type RotateOptions struct {
+	// MaxSize is the maximum size in megabytes a log file can reach before
+	// it's rotated. A zero value disables size-based rotation.
+	MaxSize int
+	// MaxAge is the maximum number of days to retain rotated log files.
+	// Older files are pruned on rotation. A zero value disables age-based
+	// pruning.
+	MaxAge int
+	// MaxBackups is the maximum number of rotated log files to keep,
+	// oldest first. A zero value disables the backup count cap.
+	MaxBackups int
+	// Compress gzip-compresses rotated segments after rotation.
+	Compress bool
+}
+
+// RotatingFileWriter is an io.Writer that writes to a file, rotating it by
+// size, pruning rotated segments by age and count, and optionally
+// gzip-compressing them, so production users don't need to pull in
+// lumberjack for basic log file hygiene. Rotation renames the current file
+// aside and reopens the original path, so log shippers tailing the inode
+// see a fresh file rather than a truncated one.
+type RotatingFileWriter struct {
+	path string
+	opts RotateOptions
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// NewRotatingFileWriter creates a RotatingFileWriter for path, creating
+// parent directories and opening (or creating) the file for append.
+func NewRotatingFileWriter(path string, opts RotateOptions) (*RotatingFileWriter, error) {
+	w := &RotatingFileWriter{path: path, opts: opts}
+	if err := w.openExisting(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *RotatingFileWriter) openExisting() error {
+	if dir := filepath.Dir(w.path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("logx: create log directory: %w", err)
+		}
+	}
+	f, err := os.OpenFile(w.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("logx: open log file: %w", err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("logx: stat log file: %w", err)
+	}
+	w.file = f
+	w.size = info.Size()
+	return nil
+}
+
+// Write appends p to the current log file, rotating first if it would
+// exceed MaxSize. Concurrent callers are serialized with a mutex.
+func (w *RotatingFileWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.opts.MaxSize > 0 && w.size+int64(len(p)) > int64(w.opts.MaxSize)*1024*1024 {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+// rotate closes the current file, renames it aside with a timestamp
+// suffix, reopens the original path fresh, and prunes old segments. The
+// caller must hold w.mu.
+func (w *RotatingFileWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("logx: close log file for rotation: %w", err)
+	}
+
+	rotated := fmt.Sprintf("%s.%s", w.path, time.Now().UTC().Format("20060102T150405.000000000"))
+	if err := os.Rename(w.path, rotated); err != nil {
+		return fmt.Errorf("logx: rename log file for rotation: %w", err)
+	}
+
+	if err := w.openExisting(); err != nil {
+		return err
+	}
+
+	if w.opts.Compress {
+		if err := compressFile(rotated); err != nil {
+			return err
+		}
+	}
+
+	return w.prune()
+}
+
+// compressFile gzips path in place, replacing it with path+".gz".
+func compressFile(path string) error {
+	src, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("logx: open rotated segment for compression: %w", err)
+	}
+	defer src.Close()
+
+	dst, err := os.Create(path + ".gz")
+	if err != nil {
+		return fmt.Errorf("logx: create compressed segment: %w", err)
+	}
+	gw := gzip.NewWriter(dst)
+	if _, err := io.Copy(gw, src); err != nil {
+		gw.Close()
+		dst.Close()
+		return fmt.Errorf("logx: compress rotated segment: %w", err)
+	}
+	if err := gw.Close(); err != nil {
+		dst.Close()
+		return fmt.Errorf("logx: finish compressed segment: %w", err)
+	}
+	if err := dst.Close(); err != nil {
+		return fmt.Errorf("logx: finish compressed segment: %w", err)
+	}
+	return os.Remove(path)
+}
+
+// prune removes rotated segments beyond MaxAge or MaxBackups. The caller
+// must hold w.mu.
+func (w *RotatingFileWriter) prune() error {
+	if w.opts.MaxAge <= 0 && w.opts.MaxBackups <= 0 {
+		return nil
+	}
+
+	dir := filepath.Dir(w.path)
+	base := filepath.Base(w.path)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("logx: list log directory for pruning: %w", err)
+	}
+
+	type segment struct {
+		path    string
+		modTime time.Time
+	}
+	var segments []segment
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasPrefix(e.Name(), base+".") {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		segments = append(segments, segment{path: filepath.Join(dir, e.Name()), modTime: info.ModTime()})
+	}
+	sort.Slice(segments, func(i, j int) bool { return segments[i].modTime.Before(segments[j].modTime) })
+
+	if w.opts.MaxAge > 0 {
+		cutoff := time.Now().Add(-time.Duration(w.opts.MaxAge) * 24 * time.Hour)
+		kept := segments[:0]
+		for _, s := range segments {
+			if s.modTime.Before(cutoff) {
+				os.Remove(s.path)
+				continue
+			}
+			kept = append(kept, s)
+		}
+		segments = kept
+	}
+
+	if w.opts.MaxBackups > 0 && len(segments) > w.opts.MaxBackups {
+		for _, s := range segments[:len(segments)-w.opts.MaxBackups] {
+			os.Remove(s.path)
+		}
+	}
+
+	return nil
+}
+
+// Close closes the underlying file.
+func (w *RotatingFileWriter) Close() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.file.Close()
+}
+
+// WithRotatingFile configures the logger to write to path through a
+// RotatingFileWriter built from opts. If the writer can't be created (e.g.
+// the directory isn't writable), the logger falls back to stdout so
+// Register doesn't need to return an error, but the failure is not
+// swallowed: it's printed to stderr and recorded on Options, retrievable
+// via Options.Err after NewOptions, so a misconfigured production log
+// path doesn't silently vanish.
+func WithRotatingFile(path string, opts RotateOptions) OptionsFunc {
+	return func(o *Options) {
+		w, err := NewRotatingFileWriter(path, opts)
+		if err != nil {
+			if o.err == nil {
+				o.err = fmt.Errorf("logx: WithRotatingFile(%q): %w", path, err)
+			}
+			fmt.Fprintf(os.Stderr, "logx: WithRotatingFile(%q) failed, falling back to stdout: %v\n", path, err)
+			o.outputs = []io.Writer{os.Stdout}
+			return
+		}
+		o.outputs = []io.Writer{w}
+	}
+}
@@ -0,0 +1,204 @@
+package logx
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRotatingFileWriterRotatesBySize(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "app.log")
+
+	w, err := NewRotatingFileWriter(path, RotateOptions{MaxSize: 1})
+	if err != nil {
+		t.Fatalf("NewRotatingFileWriter() error = %v", err)
+	}
+	defer w.Close()
+
+	chunk := strings.Repeat("x", 1024*1024)
+	if _, err := w.Write([]byte(chunk)); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if _, err := w.Write([]byte(chunk)); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	entries, err := os.ReadDir(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	var rotated int
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), "app.log.") {
+			rotated++
+		}
+	}
+	if rotated == 0 {
+		t.Error("expected at least one rotated segment after exceeding MaxSize")
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("expected the active log file to still exist at %s: %v", path, err)
+	}
+}
+
+func TestRotatingFileWriterMaxBackups(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "app.log")
+
+	w, err := NewRotatingFileWriter(path, RotateOptions{MaxSize: 1, MaxBackups: 1})
+	if err != nil {
+		t.Fatalf("NewRotatingFileWriter() error = %v", err)
+	}
+	defer w.Close()
+
+	chunk := []byte(strings.Repeat("x", 1024*1024))
+	for i := 0; i < 4; i++ {
+		if _, err := w.Write(chunk); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+
+	entries, err := os.ReadDir(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	var rotated int
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), "app.log.") {
+			rotated++
+		}
+	}
+	if rotated > 1 {
+		t.Errorf("expected MaxBackups=1 to cap rotated segments at 1, got %d", rotated)
+	}
+}
+
+func TestRotatingFileWriterMaxAge(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "app.log")
+
+	w, err := NewRotatingFileWriter(path, RotateOptions{MaxSize: 1, MaxAge: 1})
+	if err != nil {
+		t.Fatalf("NewRotatingFileWriter() error = %v", err)
+	}
+	defer w.Close()
+
+	chunk := []byte(strings.Repeat("x", 1024*1024))
+	if _, err := w.Write(chunk); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if _, err := w.Write(chunk); err != nil { // triggers the first rotation
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	entries, err := os.ReadDir(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	var firstRotated string
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), "app.log.") {
+			firstRotated = filepath.Join(tmpDir, e.Name())
+		}
+	}
+	if firstRotated == "" {
+		t.Fatal("expected a rotated segment after the first rotation")
+	}
+
+	old := time.Now().Add(-3 * 24 * time.Hour)
+	if err := os.Chtimes(firstRotated, old, old); err != nil {
+		t.Fatalf("Chtimes() error = %v", err)
+	}
+
+	if _, err := w.Write(chunk); err != nil { // triggers the second rotation, which prunes by MaxAge
+		t.Fatalf("Write() error = %v", err)
+	}
+	if _, err := w.Write(chunk); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	if _, err := os.Stat(firstRotated); !os.IsNotExist(err) {
+		t.Errorf("expected the aged-out segment %s to be pruned, stat err = %v", firstRotated, err)
+	}
+}
+
+func TestRotatingFileWriterCompress(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "app.log")
+
+	w, err := NewRotatingFileWriter(path, RotateOptions{MaxSize: 1, Compress: true})
+	if err != nil {
+		t.Fatalf("NewRotatingFileWriter() error = %v", err)
+	}
+	defer w.Close()
+
+	chunk := []byte(strings.Repeat("x", 1024*1024))
+	for i := 0; i < 2; i++ {
+		if _, err := w.Write(chunk); err != nil {
+			t.Fatalf("Write() error = %v", err)
+		}
+	}
+
+	entries, err := os.ReadDir(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadDir() error = %v", err)
+	}
+	var gz, uncompressed int
+	for _, e := range entries {
+		switch {
+		case !strings.HasPrefix(e.Name(), "app.log."):
+			continue
+		case strings.HasSuffix(e.Name(), ".gz"):
+			gz++
+		default:
+			uncompressed++
+		}
+	}
+	if gz == 0 {
+		t.Error("expected a gzip-compressed rotated segment")
+	}
+	if uncompressed != 0 {
+		t.Errorf("expected the uncompressed rotated segment to be removed after compression, found %d leftover", uncompressed)
+	}
+}
+
+func TestWithRotatingFileRecordsError(t *testing.T) {
+	tmpDir := t.TempDir()
+	blocker := filepath.Join(tmpDir, "blocker")
+	if err := os.WriteFile(blocker, []byte("x"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	badPath := filepath.Join(blocker, "sub", "app.log") // blocker is a file, not a dir: MkdirAll must fail
+
+	opts := NewOptions(WithRotatingFile(badPath, RotateOptions{MaxSize: 10}))
+	if opts.Err() == nil {
+		t.Fatal("expected Options.Err() to report the failure to open the rotating file")
+	}
+
+	// The logger must still be usable; it falls back to stdout rather than
+	// dropping every subsequent log call.
+	logger := Register("test-rotating-file-error", opts)
+	logger.InfoContext(context.Background(), "hello")
+}
+
+func TestWithRotatingFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "module.log")
+
+	opts := NewOptions(WithRotatingFile(path, RotateOptions{MaxSize: 10}))
+	logger := Register("test-rotating-file", opts)
+
+	logger.InfoContext(context.Background(), "hello")
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("expected log file to exist: %v", err)
+	}
+	if len(content) == 0 {
+		t.Error("expected log file to contain content")
+	}
+}